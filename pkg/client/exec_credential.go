@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+)
+
+// execCredential is the "client.authentication.k8s.io" wire format emitted by an exec credential
+// plugin on stdout.  Only the fields a caller needs to refresh a transport are decoded.
+type execCredential struct {
+	Status *execCredentialStatus `json:"status"`
+}
+
+type execCredentialStatus struct {
+	ExpirationTimestamp   *time.Time `json:"expirationTimestamp"`
+	Token                 string     `json:"token"`
+	ClientCertificateData string     `json:"clientCertificateData"`
+	ClientKeyData         string     `json:"clientKeyData"`
+}
+
+// WrapTransportForAuthInfo is the integration point a client constructor (e.g. osclient.New) would
+// call while building its transport: when authInfo carries an Exec stanza it wraps rt with an
+// ExecCredentialAuthenticator, otherwise it returns rt untouched so the existing static-token/
+// client-cert path is unaffected.  osclient.New's implementation isn't part of this tree's
+// extraction, so there's no caller of this yet.
+func WrapTransportForAuthInfo(rt http.RoundTripper, authInfo *clientcmdapi.AuthInfo) http.RoundTripper {
+	if authInfo == nil || authInfo.Exec == nil {
+		return rt
+	}
+	return NewExecCredentialAuthenticator(authInfo.Exec).WrapTransport(rt)
+}
+
+// ExecCredentialAuthenticator runs an exec-based credential plugin (as described by a
+// clientcmdapi.ExecConfig) and caches the token/certificate it returns until it expires.
+// WrapTransportForAuthInfo wires one of these in front of a transport whenever an AuthInfo carries
+// an Exec stanza, so a 401 from the API server triggers Refresh instead of a hard failure.
+type ExecCredentialAuthenticator struct {
+	config *clientcmdapi.ExecConfig
+
+	lock   sync.Mutex
+	cached *execCredentialStatus
+}
+
+// NewExecCredentialAuthenticator returns an authenticator that shells out to config.Command on
+// demand.  It does nothing until Token (or TLSCredentials) is first called.
+func NewExecCredentialAuthenticator(config *clientcmdapi.ExecConfig) *ExecCredentialAuthenticator {
+	return &ExecCredentialAuthenticator{config: config}
+}
+
+// Token returns a bearer token from the plugin, refreshing it if the cached one has expired.
+func (a *ExecCredentialAuthenticator) Token() (string, error) {
+	status, err := a.currentStatus()
+	if err != nil {
+		return "", err
+	}
+	return status.Token, nil
+}
+
+// TLSCredentials returns the client certificate/key pair (PEM-encoded) from the plugin, refreshing
+// it if the cached pair has expired.
+func (a *ExecCredentialAuthenticator) TLSCredentials() (certData, keyData []byte, err error) {
+	status, err := a.currentStatus()
+	if err != nil {
+		return nil, nil, err
+	}
+	return []byte(status.ClientCertificateData), []byte(status.ClientKeyData), nil
+}
+
+// Refresh discards any cached credential, forcing the next call to Token/TLSCredentials to
+// re-invoke the plugin.  execRoundTripper calls this after a 401.
+func (a *ExecCredentialAuthenticator) Refresh() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.cached = nil
+}
+
+// WrapTransport returns an http.RoundTripper that sets a bearer token obtained from the exec
+// plugin on every request and, when the API server responds 401, discards the cached credential
+// and retries the request once with a freshly-minted one (when the request body can be replayed).
+// WrapTransportForAuthInfo calls this for any AuthInfo carrying an Exec stanza instead of setting a
+// static Authorization header.
+func (a *ExecCredentialAuthenticator) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &execRoundTripper{authenticator: a, delegate: rt}
+}
+
+type execRoundTripper struct {
+	authenticator *ExecCredentialAuthenticator
+	delegate      http.RoundTripper
+}
+
+func (t *execRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.authenticator.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.delegate.RoundTrip(setBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// req's body, if any, was already drained by the first RoundTrip. Without GetBody there's no
+	// way to replay it, so retrying would silently send an empty body on a POST/PUT/PATCH; give up
+	// and return the 401 instead of corrupting the request.
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	resp.Body.Close()
+
+	t.authenticator.Refresh()
+	token, err = t.authenticator.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	retryReq := setBearerToken(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retryReq.Body = body
+	}
+
+	return t.delegate.RoundTrip(retryReq)
+}
+
+// setBearerToken returns a shallow copy of req (per http.RoundTripper's contract against
+// mutating the original request) with the Authorization header set.
+func setBearerToken(req *http.Request, token string) *http.Request {
+	clone := *req
+	clone.Header = req.Header.Clone()
+	clone.Header.Set("Authorization", "Bearer "+token)
+	return &clone
+}
+
+func (a *ExecCredentialAuthenticator) currentStatus() (*execCredentialStatus, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.cached != nil && (a.cached.ExpirationTimestamp == nil || a.cached.ExpirationTimestamp.After(time.Now())) {
+		return a.cached, nil
+	}
+
+	status, err := a.execPlugin()
+	if err != nil {
+		return nil, err
+	}
+	a.cached = status
+	return status, nil
+}
+
+func (a *ExecCredentialAuthenticator) execPlugin() (*execCredentialStatus, error) {
+	cmd := exec.Command(a.config.Command, a.config.Args...)
+	cmd.Env = os.Environ()
+	for _, env := range a.config.Env {
+		cmd.Env = append(cmd.Env, env.Name+"="+env.Value)
+	}
+	cmd.Stderr = os.Stderr
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		hint := a.config.InstallHint
+		if len(hint) > 0 {
+			return nil, fmt.Errorf("exec plugin %q failed: %v\n%s", a.config.Command, err, hint)
+		}
+		return nil, fmt.Errorf("exec plugin %q failed: %v", a.config.Command, err)
+	}
+
+	credential := &execCredential{}
+	if err := json.Unmarshal(stdout.Bytes(), credential); err != nil {
+		return nil, fmt.Errorf("exec plugin %q returned invalid ExecCredential JSON: %v", a.config.Command, err)
+	}
+	if credential.Status == nil {
+		return nil, fmt.Errorf("exec plugin %q returned no status", a.config.Command)
+	}
+
+	return credential.Status, nil
+}
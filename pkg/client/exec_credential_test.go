@@ -0,0 +1,185 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"testing"
+
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+)
+
+// countingExecPlugin returns an ExecConfig for a shell one-liner that bumps a counter file on
+// every invocation and prints "token-<n>", so tests can tell a cached token apart from one
+// obtained by a fresh call into the plugin.
+func countingExecPlugin(t *testing.T) (*clientcmdapi.ExecConfig, func()) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skipf("no sh binary available to drive the exec plugin: %v", err)
+	}
+
+	counter, err := ioutil.TempFile("", "exec-plugin-counter")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	counter.WriteString("0")
+	counter.Close()
+
+	script := fmt.Sprintf(`n=$(( $(cat %s) + 1 )); echo -n $n > %s; echo "{\"status\":{\"token\":\"token-$n\"}}"`, counter.Name(), counter.Name())
+
+	return &clientcmdapi.ExecConfig{Command: sh, Args: []string{"-c", script}}, func() { os.Remove(counter.Name()) }
+}
+
+func TestExecCredentialAuthenticatorCachesToken(t *testing.T) {
+	config, cleanup := countingExecPlugin(t)
+	defer cleanup()
+	a := NewExecCredentialAuthenticator(config)
+
+	first, err := a.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != "token-1" {
+		t.Fatalf("expected token-1, got %q", first)
+	}
+
+	second, err := a.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second != "token-1" {
+		t.Errorf("expected the cached token to be reused without re-running the plugin, got %q", second)
+	}
+}
+
+func TestExecCredentialAuthenticatorRefreshReRunsPlugin(t *testing.T) {
+	config, cleanup := countingExecPlugin(t)
+	defer cleanup()
+	a := NewExecCredentialAuthenticator(config)
+
+	if _, err := a.Token(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a.Refresh()
+
+	second, err := a.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second != "token-2" {
+		t.Errorf("expected Refresh to force the plugin to run again, got %q", second)
+	}
+}
+
+func TestExecRoundTripperRefreshesOn401(t *testing.T) {
+	config, cleanup := countingExecPlugin(t)
+	defer cleanup()
+	a := NewExecCredentialAuthenticator(config)
+
+	seenTokens := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token := req.Header.Get("Authorization")
+		seenTokens = append(seenTokens, token)
+		if token == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rt := a.WrapTransport(http.DefaultTransport)
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the retried request to succeed, got %d", resp.StatusCode)
+	}
+
+	if len(seenTokens) != 2 || seenTokens[0] != "Bearer token-1" || seenTokens[1] != "Bearer token-2" {
+		t.Errorf("expected a stale token followed by a refreshed one, got %v", seenTokens)
+	}
+}
+
+func TestExecRoundTripperReplaysBodyOnRefresh(t *testing.T) {
+	config, cleanup := countingExecPlugin(t)
+	defer cleanup()
+	a := NewExecCredentialAuthenticator(config)
+
+	seenBodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		seenBodies = append(seenBodies, string(body))
+		if req.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rt := a.WrapTransport(http.DefaultTransport)
+
+	req, err := http.NewRequest("POST", server.URL, bytes.NewReader([]byte("payload")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the retried request to succeed, got %d", resp.StatusCode)
+	}
+
+	if len(seenBodies) != 2 || seenBodies[0] != "payload" || seenBodies[1] != "payload" {
+		t.Errorf("expected the retried request to replay the original body, got %v", seenBodies)
+	}
+}
+
+func TestExecRoundTripperGivesUpWhenBodyCannotBeReplayed(t *testing.T) {
+	config, cleanup := countingExecPlugin(t)
+	defer cleanup()
+	a := NewExecCredentialAuthenticator(config)
+
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	rt := a.WrapTransport(http.DefaultTransport)
+
+	req, err := http.NewRequest("POST", server.URL, bytes.NewReader([]byte("payload")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Simulate a body http.NewRequest couldn't wrap a GetBody for (e.g. an arbitrary io.Reader),
+	// which drops the ability to replay it on retry.
+	req.GetBody = nil
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected the original 401 to be returned when the body can't be replayed, got %d", resp.StatusCode)
+	}
+	if requestCount != 1 {
+		t.Errorf("expected no retry when the body can't be replayed, got %d requests", requestCount)
+	}
+}
@@ -0,0 +1,274 @@
+package client
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+
+	kapierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/meta"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// FakeAction is one call made against a fake client in this package, recorded on Fake.Actions in
+// a shape callers can assert against directly (verb + resource, the way "oc" callers do) instead
+// of parsing an ad-hoc string.
+type FakeAction struct {
+	Verb      string
+	Resource  string
+	Namespace string
+	Name      string
+	Object    runtime.Object
+}
+
+// ReactionFunc lets a test replace the result a resource's ObjectTracker produced for a
+// FakeAction, either to inject an error or to substitute its own response.  The tracker has
+// already applied the action by the time a reactor runs (a Create is stored even when a reactor
+// returns an error for it).  Returning handled=false lets the action fall through to the next
+// reactor.
+type ReactionFunc func(action FakeAction) (handled bool, ret runtime.Object, err error)
+
+type reactor struct {
+	verb, resource string
+	react          ReactionFunc
+}
+
+// Fake is the action-tracking, reactor-driven base shared by every fake authorization client in
+// this package: FakeRoles, FakeRoleBindings, FakeClusterRoles, FakeClusterRoleBindings and
+// FakePolicies each embed a *Fake and drive it through Invokes.
+type Fake struct {
+	lock sync.Mutex
+
+	Actions []FakeAction
+
+	reactionChain []reactor
+}
+
+// PrependReactor registers fn to run before any reactor already registered for actions matching
+// verb and resource; "*" matches anything in either position.  A reactor that handles an action
+// replaces the result of the resource's ObjectTracker.  This lets a test inject an error or mutate
+// a response without having to patch the fake itself.
+func (f *Fake) PrependReactor(verb, resource string, fn ReactionFunc) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.reactionChain = append([]reactor{{verb, resource, fn}}, f.reactionChain...)
+}
+
+// Invokes records action on Fake.Actions, then gives every registered reactor a chance to handle
+// it (most recently prepended first).  If none does, it returns defaultReturnObj/defaultErr, which
+// callers compute by running the request against their ObjectTracker first.
+func (f *Fake) Invokes(action FakeAction, defaultReturnObj runtime.Object, defaultErr error) (runtime.Object, error) {
+	f.lock.Lock()
+	f.Actions = append(f.Actions, action)
+	chain := f.reactionChain
+	f.lock.Unlock()
+
+	for _, r := range chain {
+		if r.verb != "*" && r.verb != action.Verb {
+			continue
+		}
+		if r.resource != "*" && r.resource != action.Resource {
+			continue
+		}
+		if handled, ret, err := r.react(action); handled {
+			return ret, err
+		}
+	}
+
+	return defaultReturnObj, defaultErr
+}
+
+// ObjectTracker is a naive in-memory store keyed by (namespace, name), shared by every fake
+// authorization client below.  It understands enough of an object's metadata (via
+// pkg/api/meta.Accessor) to filter List/Watch by label and field selector, to return a proper
+// NotFound from Get/Delete, to bump ResourceVersion on Create/Update, and to fan Watch events out
+// to every open watch.Interface.
+type ObjectTracker struct {
+	kind string
+
+	lock     sync.Mutex
+	objects  map[string]runtime.Object
+	rv       int
+	watchers []*trackerWatch
+}
+
+// NewObjectTracker returns an empty tracker.  kind is used only to build the NotFound error
+// Get/Delete return for a missing object (e.g. "Role", "ClusterRoleBinding").
+func NewObjectTracker(kind string) *ObjectTracker {
+	return &ObjectTracker{kind: kind, objects: map[string]runtime.Object{}}
+}
+
+func trackerKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func (t *ObjectTracker) Get(namespace, name string) (runtime.Object, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	obj, exists := t.objects[trackerKey(namespace, name)]
+	if !exists {
+		return nil, kapierrors.NewNotFound(t.kind, name)
+	}
+	return obj, nil
+}
+
+// List returns every stored object in namespace (all namespaces when empty) that matches label
+// and field; either selector may be nil to mean "match everything".
+func (t *ObjectTracker) List(namespace string, label labels.Selector, field fields.Selector) []runtime.Object {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ret := []runtime.Object{}
+	for _, obj := range t.objects {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			continue
+		}
+		if len(namespace) > 0 && accessor.GetNamespace() != namespace {
+			continue
+		}
+		if label != nil && !label.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		if field != nil && !field.Matches(fields.Set{"metadata.name": accessor.GetName()}) {
+			continue
+		}
+		ret = append(ret, obj)
+	}
+	return ret
+}
+
+// Create and Update both simply upsert; this fake doesn't need to distinguish "already exists"
+// from "replaced" for the callers exercising it today.
+func (t *ObjectTracker) Create(obj runtime.Object) (runtime.Object, error) {
+	return t.upsert(obj)
+}
+
+func (t *ObjectTracker) Update(obj runtime.Object) (runtime.Object, error) {
+	return t.upsert(obj)
+}
+
+func (t *ObjectTracker) upsert(obj runtime.Object) (runtime.Object, error) {
+	// Store (and return) a copy rather than the caller's own pointer: otherwise bumping
+	// ResourceVersion below would mutate the object the caller already holds from a prior
+	// Create/Update, making every version of it compare equal.
+	stored := copyObject(obj)
+	accessor, err := meta.Accessor(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	t.lock.Lock()
+	t.rv++
+	accessor.SetResourceVersion(strconv.Itoa(t.rv))
+
+	key := trackerKey(accessor.GetNamespace(), accessor.GetName())
+	_, existed := t.objects[key]
+	t.objects[key] = stored
+	watchers := append([]*trackerWatch{}, t.watchers...)
+	t.lock.Unlock()
+
+	eventType := watch.Added
+	if existed {
+		eventType = watch.Modified
+	}
+	notify(watchers, eventType, stored)
+
+	return stored, nil
+}
+
+// copyObject returns a shallow copy of obj's underlying struct so the tracker never hands out or
+// stores the same pointer a caller already holds.
+func copyObject(obj runtime.Object) runtime.Object {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr {
+		return obj
+	}
+	cp := reflect.New(v.Elem().Type())
+	cp.Elem().Set(v.Elem())
+	return cp.Interface().(runtime.Object)
+}
+
+func (t *ObjectTracker) Delete(namespace, name string) error {
+	t.lock.Lock()
+	key := trackerKey(namespace, name)
+	obj, exists := t.objects[key]
+	if !exists {
+		t.lock.Unlock()
+		return kapierrors.NewNotFound(t.kind, name)
+	}
+	delete(t.objects, key)
+	watchers := append([]*trackerWatch{}, t.watchers...)
+	t.lock.Unlock()
+
+	notify(watchers, watch.Deleted, obj)
+	return nil
+}
+
+// Watch returns a watch.Interface that receives Added/Modified/Deleted events for every future
+// Create/Update/Delete matching label and field.  resourceVersion is accepted for interface
+// compatibility but this fake always watches from "now", the same way the tracked resources'
+// blind in-memory storage doesn't support historical resourceVersions either.
+func (t *ObjectTracker) Watch(label labels.Selector, field fields.Selector, resourceVersion string) watch.Interface {
+	w := &trackerWatch{result: make(chan watch.Event, 100), label: label, field: field}
+
+	t.lock.Lock()
+	t.watchers = append(t.watchers, w)
+	t.lock.Unlock()
+
+	return w
+}
+
+func notify(watchers []*trackerWatch, eventType watch.EventType, obj runtime.Object) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	for _, w := range watchers {
+		if w.label != nil && !w.label.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		if w.field != nil && !w.field.Matches(fields.Set{"metadata.name": accessor.GetName()}) {
+			continue
+		}
+		w.send(watch.Event{Type: eventType, Object: obj})
+	}
+}
+
+type trackerWatch struct {
+	lock    sync.Mutex
+	result  chan watch.Event
+	label   labels.Selector
+	field   fields.Selector
+	stopped bool
+}
+
+func (w *trackerWatch) ResultChan() <-chan watch.Event { return w.result }
+
+// send delivers event unless the watch has already been stopped.  It shares w.lock with Stop so a
+// concurrent Stop can never close result out from under a send, and it never blocks: a watcher
+// that isn't keeping up with its buffer drops the event instead of stalling the producer.
+func (w *trackerWatch) send(event watch.Event) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.stopped {
+		return
+	}
+	select {
+	case w.result <- event:
+	default:
+	}
+}
+
+func (w *trackerWatch) Stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if !w.stopped {
+		w.stopped = true
+		close(w.result)
+	}
+}
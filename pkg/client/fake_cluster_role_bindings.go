@@ -0,0 +1,77 @@
+package client
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+)
+
+// FakeClusterRoleBindings is an action-tracking fake for the cluster-scoped ClusterRoleBindings
+// client.  See FakeRoles for the ObjectTracker-backed behavior it shares.
+type FakeClusterRoleBindings struct {
+	Fake    *Fake
+	Tracker *ObjectTracker
+}
+
+func NewFakeClusterRoleBindings(fake *Fake) *FakeClusterRoleBindings {
+	return &FakeClusterRoleBindings{Fake: fake, Tracker: NewObjectTracker("ClusterRoleBinding")}
+}
+
+func (c *FakeClusterRoleBindings) List(label labels.Selector, field fields.Selector) (*authorizationapi.ClusterRoleBindingList, error) {
+	list := &authorizationapi.ClusterRoleBindingList{}
+	for _, obj := range c.Tracker.List("", label, field) {
+		list.Items = append(list.Items, *obj.(*authorizationapi.ClusterRoleBinding))
+	}
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "list", Resource: "clusterrolebindings"}, list, nil)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.ClusterRoleBindingList), nil
+}
+
+func (c *FakeClusterRoleBindings) Get(name string) (*authorizationapi.ClusterRoleBinding, error) {
+	tracked, trackedErr := c.Tracker.Get("", name)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "get", Resource: "clusterrolebindings", Name: name}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.ClusterRoleBinding), nil
+}
+
+func (c *FakeClusterRoleBindings) Create(roleBinding *authorizationapi.ClusterRoleBinding) (*authorizationapi.ClusterRoleBinding, error) {
+	tracked, trackedErr := c.Tracker.Create(roleBinding)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "create", Resource: "clusterrolebindings", Name: roleBinding.Name, Object: roleBinding}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.ClusterRoleBinding), nil
+}
+
+func (c *FakeClusterRoleBindings) Update(roleBinding *authorizationapi.ClusterRoleBinding) (*authorizationapi.ClusterRoleBinding, error) {
+	tracked, trackedErr := c.Tracker.Update(roleBinding)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "update", Resource: "clusterrolebindings", Name: roleBinding.Name, Object: roleBinding}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.ClusterRoleBinding), nil
+}
+
+func (c *FakeClusterRoleBindings) Delete(name string) error {
+	trackedErr := c.Tracker.Delete("", name)
+
+	_, err := c.Fake.Invokes(FakeAction{Verb: "delete", Resource: "clusterrolebindings", Name: name}, nil, trackedErr)
+	return err
+}
+
+func (c *FakeClusterRoleBindings) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	if _, err := c.Fake.Invokes(FakeAction{Verb: "watch", Resource: "clusterrolebindings"}, nil, nil); err != nil {
+		return nil, err
+	}
+	return c.Tracker.Watch(label, field, resourceVersion), nil
+}
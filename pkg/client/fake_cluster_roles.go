@@ -0,0 +1,77 @@
+package client
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+)
+
+// FakeClusterRoles is an action-tracking fake for the cluster-scoped ClusterRoles client.  See
+// FakeRoles for the ObjectTracker-backed behavior it shares.
+type FakeClusterRoles struct {
+	Fake    *Fake
+	Tracker *ObjectTracker
+}
+
+func NewFakeClusterRoles(fake *Fake) *FakeClusterRoles {
+	return &FakeClusterRoles{Fake: fake, Tracker: NewObjectTracker("ClusterRole")}
+}
+
+func (c *FakeClusterRoles) List(label labels.Selector, field fields.Selector) (*authorizationapi.ClusterRoleList, error) {
+	list := &authorizationapi.ClusterRoleList{}
+	for _, obj := range c.Tracker.List("", label, field) {
+		list.Items = append(list.Items, *obj.(*authorizationapi.ClusterRole))
+	}
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "list", Resource: "clusterroles"}, list, nil)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.ClusterRoleList), nil
+}
+
+func (c *FakeClusterRoles) Get(name string) (*authorizationapi.ClusterRole, error) {
+	tracked, trackedErr := c.Tracker.Get("", name)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "get", Resource: "clusterroles", Name: name}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.ClusterRole), nil
+}
+
+func (c *FakeClusterRoles) Create(role *authorizationapi.ClusterRole) (*authorizationapi.ClusterRole, error) {
+	tracked, trackedErr := c.Tracker.Create(role)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "create", Resource: "clusterroles", Name: role.Name, Object: role}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.ClusterRole), nil
+}
+
+func (c *FakeClusterRoles) Update(role *authorizationapi.ClusterRole) (*authorizationapi.ClusterRole, error) {
+	tracked, trackedErr := c.Tracker.Update(role)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "update", Resource: "clusterroles", Name: role.Name, Object: role}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.ClusterRole), nil
+}
+
+func (c *FakeClusterRoles) Delete(name string) error {
+	trackedErr := c.Tracker.Delete("", name)
+
+	_, err := c.Fake.Invokes(FakeAction{Verb: "delete", Resource: "clusterroles", Name: name}, nil, trackedErr)
+	return err
+}
+
+func (c *FakeClusterRoles) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	if _, err := c.Fake.Invokes(FakeAction{Verb: "watch", Resource: "clusterroles"}, nil, nil); err != nil {
+		return nil, err
+	}
+	return c.Tracker.Watch(label, field, resourceVersion), nil
+}
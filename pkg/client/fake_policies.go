@@ -0,0 +1,78 @@
+package client
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+)
+
+// FakePolicies is an action-tracking fake for the Policies client, scoped to a single namespace.
+// See FakeRoles for the ObjectTracker-backed behavior it shares.
+type FakePolicies struct {
+	Fake      *Fake
+	Namespace string
+	Tracker   *ObjectTracker
+}
+
+func NewFakePolicies(fake *Fake, namespace string) *FakePolicies {
+	return &FakePolicies{Fake: fake, Namespace: namespace, Tracker: NewObjectTracker("Policy")}
+}
+
+func (c *FakePolicies) List(label labels.Selector, field fields.Selector) (*authorizationapi.PolicyList, error) {
+	list := &authorizationapi.PolicyList{}
+	for _, obj := range c.Tracker.List(c.Namespace, label, field) {
+		list.Items = append(list.Items, *obj.(*authorizationapi.Policy))
+	}
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "list", Resource: "policies", Namespace: c.Namespace}, list, nil)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.PolicyList), nil
+}
+
+func (c *FakePolicies) Get(name string) (*authorizationapi.Policy, error) {
+	tracked, trackedErr := c.Tracker.Get(c.Namespace, name)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "get", Resource: "policies", Namespace: c.Namespace, Name: name}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.Policy), nil
+}
+
+func (c *FakePolicies) Create(policy *authorizationapi.Policy) (*authorizationapi.Policy, error) {
+	tracked, trackedErr := c.Tracker.Create(policy)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "create", Resource: "policies", Namespace: c.Namespace, Name: policy.Name, Object: policy}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.Policy), nil
+}
+
+func (c *FakePolicies) Update(policy *authorizationapi.Policy) (*authorizationapi.Policy, error) {
+	tracked, trackedErr := c.Tracker.Update(policy)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "update", Resource: "policies", Namespace: c.Namespace, Name: policy.Name, Object: policy}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.Policy), nil
+}
+
+func (c *FakePolicies) Delete(name string) error {
+	trackedErr := c.Tracker.Delete(c.Namespace, name)
+
+	_, err := c.Fake.Invokes(FakeAction{Verb: "delete", Resource: "policies", Namespace: c.Namespace, Name: name}, nil, trackedErr)
+	return err
+}
+
+func (c *FakePolicies) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	if _, err := c.Fake.Invokes(FakeAction{Verb: "watch", Resource: "policies", Namespace: c.Namespace}, nil, nil); err != nil {
+		return nil, err
+	}
+	return c.Tracker.Watch(label, field, resourceVersion), nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+)
+
+// FakeRoleBindings is an action-tracking fake for the RoleBindings client, scoped to a single
+// namespace.  See FakeRoles for the ObjectTracker-backed behavior it shares.
+type FakeRoleBindings struct {
+	Fake      *Fake
+	Namespace string
+	Tracker   *ObjectTracker
+}
+
+func NewFakeRoleBindings(fake *Fake, namespace string) *FakeRoleBindings {
+	return &FakeRoleBindings{Fake: fake, Namespace: namespace, Tracker: NewObjectTracker("RoleBinding")}
+}
+
+func (c *FakeRoleBindings) List(label labels.Selector, field fields.Selector) (*authorizationapi.RoleBindingList, error) {
+	list := &authorizationapi.RoleBindingList{}
+	for _, obj := range c.Tracker.List(c.Namespace, label, field) {
+		list.Items = append(list.Items, *obj.(*authorizationapi.RoleBinding))
+	}
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "list", Resource: "rolebindings", Namespace: c.Namespace}, list, nil)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.RoleBindingList), nil
+}
+
+func (c *FakeRoleBindings) Get(name string) (*authorizationapi.RoleBinding, error) {
+	tracked, trackedErr := c.Tracker.Get(c.Namespace, name)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "get", Resource: "rolebindings", Namespace: c.Namespace, Name: name}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.RoleBinding), nil
+}
+
+func (c *FakeRoleBindings) Create(roleBinding *authorizationapi.RoleBinding) (*authorizationapi.RoleBinding, error) {
+	tracked, trackedErr := c.Tracker.Create(roleBinding)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "create", Resource: "rolebindings", Namespace: c.Namespace, Name: roleBinding.Name, Object: roleBinding}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.RoleBinding), nil
+}
+
+func (c *FakeRoleBindings) Update(roleBinding *authorizationapi.RoleBinding) (*authorizationapi.RoleBinding, error) {
+	tracked, trackedErr := c.Tracker.Update(roleBinding)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "update", Resource: "rolebindings", Namespace: c.Namespace, Name: roleBinding.Name, Object: roleBinding}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.RoleBinding), nil
+}
+
+func (c *FakeRoleBindings) Delete(name string) error {
+	trackedErr := c.Tracker.Delete(c.Namespace, name)
+
+	_, err := c.Fake.Invokes(FakeAction{Verb: "delete", Resource: "rolebindings", Namespace: c.Namespace, Name: name}, nil, trackedErr)
+	return err
+}
+
+func (c *FakeRoleBindings) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	if _, err := c.Fake.Invokes(FakeAction{Verb: "watch", Resource: "rolebindings", Namespace: c.Namespace}, nil, nil); err != nil {
+		return nil, err
+	}
+	return c.Tracker.Watch(label, field, resourceVersion), nil
+}
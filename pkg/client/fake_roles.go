@@ -3,35 +3,79 @@ package client
 import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 )
 
+// FakeRoles is an action-tracking fake for the Roles client, scoped to a single namespace the way
+// the real client.Roles(namespace) is.  It's backed by an ObjectTracker, so List/Get/Create/
+// Update/Delete/Watch behave like the real thing (selectors, NotFound, ResourceVersion, watch
+// events) instead of returning a canned value.
 type FakeRoles struct {
-	Fake *Fake
+	Fake      *Fake
+	Namespace string
+	Tracker   *ObjectTracker
+}
+
+// NewFakeRoles returns a FakeRoles backed by a fresh, empty ObjectTracker.
+func NewFakeRoles(fake *Fake, namespace string) *FakeRoles {
+	return &FakeRoles{Fake: fake, Namespace: namespace, Tracker: NewObjectTracker("Role")}
 }
 
 func (c *FakeRoles) List(label labels.Selector, field fields.Selector) (*authorizationapi.RoleList, error) {
-	obj, err := c.Fake.Invokes(FakeAction{Action: "list-role"}, &authorizationapi.RoleList{})
-	return obj.(*authorizationapi.RoleList), err
+	list := &authorizationapi.RoleList{}
+	for _, obj := range c.Tracker.List(c.Namespace, label, field) {
+		list.Items = append(list.Items, *obj.(*authorizationapi.Role))
+	}
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "list", Resource: "roles", Namespace: c.Namespace}, list, nil)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.RoleList), nil
 }
 
 func (c *FakeRoles) Get(name string) (*authorizationapi.Role, error) {
-	obj, err := c.Fake.Invokes(FakeAction{Action: "get-role"}, &authorizationapi.Role{})
-	return obj.(*authorizationapi.Role), err
+	tracked, trackedErr := c.Tracker.Get(c.Namespace, name)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "get", Resource: "roles", Namespace: c.Namespace, Name: name}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.Role), nil
 }
 
 func (c *FakeRoles) Create(role *authorizationapi.Role) (*authorizationapi.Role, error) {
-	obj, err := c.Fake.Invokes(FakeAction{Action: "create-role", Value: role}, &authorizationapi.Role{})
-	return obj.(*authorizationapi.Role), err
+	tracked, trackedErr := c.Tracker.Create(role)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "create", Resource: "roles", Namespace: c.Namespace, Name: role.Name, Object: role}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.Role), nil
 }
 
 func (c *FakeRoles) Update(role *authorizationapi.Role) (*authorizationapi.Role, error) {
-	obj, err := c.Fake.Invokes(FakeAction{Action: "update-role"}, &authorizationapi.Role{})
-	return obj.(*authorizationapi.Role), err
+	tracked, trackedErr := c.Tracker.Update(role)
+
+	obj, err := c.Fake.Invokes(FakeAction{Verb: "update", Resource: "roles", Namespace: c.Namespace, Name: role.Name, Object: role}, tracked, trackedErr)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*authorizationapi.Role), nil
 }
 
 func (c *FakeRoles) Delete(name string) error {
-	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "delete-role", Value: name})
-	return nil
+	trackedErr := c.Tracker.Delete(c.Namespace, name)
+
+	_, err := c.Fake.Invokes(FakeAction{Verb: "delete", Resource: "roles", Namespace: c.Namespace, Name: name}, nil, trackedErr)
+	return err
+}
+
+func (c *FakeRoles) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	if _, err := c.Fake.Invokes(FakeAction{Verb: "watch", Resource: "roles", Namespace: c.Namespace}, nil, nil); err != nil {
+		return nil, err
+	}
+	return c.Tracker.Watch(label, field, resourceVersion), nil
 }
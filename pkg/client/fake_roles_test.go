@@ -0,0 +1,85 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kapierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+)
+
+func TestFakeRolesGetNotFound(t *testing.T) {
+	fake := &Fake{}
+	roles := NewFakeRoles(fake, "myproject")
+
+	_, err := roles.Get("missing")
+	if err == nil {
+		t.Fatalf("expected a NotFound error")
+	}
+	if !kapierrors.IsNotFound(err) {
+		t.Errorf("expected a NotFound error, got %v", err)
+	}
+
+	if len(fake.Actions) != 1 || fake.Actions[0].Verb != "get" || fake.Actions[0].Resource != "roles" {
+		t.Errorf("expected a recorded get-roles action, got %#v", fake.Actions)
+	}
+}
+
+func TestFakeRolesListHonorsLabelSelector(t *testing.T) {
+	fake := &Fake{}
+	roles := NewFakeRoles(fake, "myproject")
+
+	if _, err := roles.Create(&authorizationapi.Role{ObjectMeta: kapi.ObjectMeta{Name: "admin", Namespace: "myproject", Labels: map[string]string{"tier": "admin"}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := roles.Create(&authorizationapi.Role{ObjectMeta: kapi.ObjectMeta{Name: "viewer", Namespace: "myproject", Labels: map[string]string{"tier": "viewer"}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list, err := roles.List(labels.SelectorFromSet(labels.Set{"tier": "admin"}), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "admin" {
+		t.Errorf("expected only the admin role to match, got %#v", list.Items)
+	}
+}
+
+func TestFakeRolesCreateBumpsResourceVersion(t *testing.T) {
+	fake := &Fake{}
+	roles := NewFakeRoles(fake, "myproject")
+
+	role := &authorizationapi.Role{ObjectMeta: kapi.ObjectMeta{Name: "admin", Namespace: "myproject"}}
+	created, err := roles.Create(role)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(created.ResourceVersion) == 0 {
+		t.Errorf("expected ResourceVersion to be set on create")
+	}
+
+	updated, err := roles.Update(created)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.ResourceVersion == created.ResourceVersion {
+		t.Errorf("expected ResourceVersion to change on update")
+	}
+}
+
+func TestFakeRolesPrependReactorOverridesTracker(t *testing.T) {
+	fake := &Fake{}
+	roles := NewFakeRoles(fake, "myproject")
+
+	fake.PrependReactor("get", "roles", func(action FakeAction) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("boom")
+	})
+
+	if _, err := roles.Get("admin"); err == nil || err.Error() != "boom" {
+		t.Errorf("expected the reactor's error to win, got %v", err)
+	}
+}
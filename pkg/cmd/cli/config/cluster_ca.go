@@ -0,0 +1,148 @@
+package config
+
+import (
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/pflag"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+)
+
+const (
+	defaultCAConfigMapNamespace = "kube-public"
+	defaultCAConfigMapName      = "kube-root-ca.crt"
+	defaultCAConfigMapKey       = "ca.crt"
+)
+
+// CASource abstracts where CreateConfigWithClusterCA reads the cluster's CA bundle from, so the
+// same "fetch the CA and embed it" mechanism can pull from a ConfigMap, a Secret, or a plain file
+// without CreateConfigWithClusterCA caring which.
+type CASource interface {
+	// GetCA returns the PEM-encoded CA bundle.  A NotFound-style error is expected and handled by
+	// CreateConfigWithClusterCA falling back to the insecure config it already built.
+	GetCA(clientCfg *client.Config) ([]byte, error)
+}
+
+// ConfigMapCASource reads the CA bundle out of a ConfigMap, defaulting to the
+// kube-public/kube-root-ca.crt convention every cluster publishes.
+type ConfigMapCASource struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+// NewConfigMapCASource returns a ConfigMapCASource pointed at the default
+// kube-public/kube-root-ca.crt ConfigMap; callers can override any field before use.
+func NewConfigMapCASource() *ConfigMapCASource {
+	return &ConfigMapCASource{Namespace: defaultCAConfigMapNamespace, Name: defaultCAConfigMapName, Key: defaultCAConfigMapKey}
+}
+
+func (s *ConfigMapCASource) GetCA(clientCfg *client.Config) ([]byte, error) {
+	kubeClient, err := client.New(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap, err := kubeClient.ConfigMaps(s.Namespace).Get(s.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, exists := configMap.Data[s.Key]
+	if !exists {
+		return nil, fmt.Errorf("configmap %s/%s has no %q key", s.Namespace, s.Name, s.Key)
+	}
+	return []byte(ca), nil
+}
+
+// SecretCASource reads the CA bundle out of a Secret, for clusters that publish their CA as a
+// Secret (e.g. a shoot-style hosted control plane) rather than a ConfigMap.
+type SecretCASource struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+func (s *SecretCASource) GetCA(clientCfg *client.Config) ([]byte, error) {
+	kubeClient, err := client.New(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := kubeClient.Secrets(s.Namespace).Get(s.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, exists := secret.Data[s.Key]
+	if !exists {
+		return nil, fmt.Errorf("secret %s/%s has no %q key", s.Namespace, s.Name, s.Key)
+	}
+	return ca, nil
+}
+
+// FileCASource reads the CA bundle from a local file, so a caller who already has the cluster's CA
+// on disk can share CreateConfigWithClusterCA's PEM validation and embedding.
+type FileCASource struct {
+	Path string
+}
+
+func (s *FileCASource) GetCA(clientCfg *client.Config) ([]byte, error) {
+	return ioutil.ReadFile(s.Path)
+}
+
+// CreateConfigWithClusterCA builds the same config CreateConfig would, then tries to replace an
+// insecure bootstrap with one that trusts the cluster's real CA: it reads the bundle from
+// caSource, validates it's well-formed PEM, writes it into the cluster stanza, and turns
+// InsecureSkipTLSVerify off.  If caSource can't produce a CA (the well-known ConfigMap doesn't
+// exist on an older cluster, say), it falls back to the config CreateConfig already built instead
+// of failing outright.  CreateConfigForLogin only takes this path when --fetch-ca-from-cluster is
+// passed.
+func CreateConfigWithClusterCA(namespace string, clientCfg *client.Config, caSource CASource) (*clientcmdapi.Config, error) {
+	config, err := CreateConfig(namespace, clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	caData, err := caSource.GetCA(clientCfg)
+	if err != nil {
+		return config, nil
+	}
+	if block, _ := pem.Decode(caData); block == nil {
+		return nil, fmt.Errorf("cluster CA is not valid PEM data")
+	}
+
+	clusterNick, err := getClusterNickname(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := config.Clusters[clusterNick]
+	cluster.CertificateAuthority = ""
+	cluster.CertificateAuthorityData = caData
+	cluster.InsecureSkipTLSVerify = false
+	config.Clusters[clusterNick] = cluster
+
+	return config, nil
+}
+
+// BindFetchCAFromClusterFlag registers --fetch-ca-from-cluster on flags, writing into
+// fetchFromCluster.  "oc login" would call this when building its command, then pass the parsed
+// value to CreateConfigForLogin - there's no "oc login" command package in this tree, so there's no
+// caller of this yet.
+func BindFetchCAFromClusterFlag(flags *pflag.FlagSet, fetchFromCluster *bool) {
+	flags.BoolVar(fetchFromCluster, "fetch-ca-from-cluster", false, "Fetch the cluster's CA bundle from the well-known kube-public/kube-root-ca.crt ConfigMap and embed it, instead of starting from an insecure bootstrap config")
+}
+
+// CreateConfigForLogin is what "oc login" would call to build its kubeconfig.  It only takes the
+// CreateConfigWithClusterCA path, reading caSource, when fetchFromCluster (bound from
+// --fetch-ca-from-cluster) is set; otherwise it behaves exactly like CreateConfig.
+func CreateConfigForLogin(namespace string, clientCfg *client.Config, caSource CASource, fetchFromCluster bool) (*clientcmdapi.Config, error) {
+	if !fetchFromCluster {
+		return CreateConfig(namespace, clientCfg)
+	}
+	return CreateConfigWithClusterCA(namespace, clientCfg, caSource)
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+)
+
+type fakeCASource struct {
+	ca  []byte
+	err error
+}
+
+func (s *fakeCASource) GetCA(clientCfg *client.Config) ([]byte, error) {
+	return s.ca, s.err
+}
+
+func TestCreateConfigWithClusterCAFallsBackWhenCAUnavailable(t *testing.T) {
+	clientCfg := &client.Config{Host: "https://foo.example.com", Insecure: true}
+
+	config, err := CreateConfigWithClusterCA("myproject", clientCfg, &fakeCASource{err: fmt.Errorf("configmap not found")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clusterNick, err := getClusterNickname(clientCfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.Clusters[clusterNick].InsecureSkipTLSVerify {
+		t.Errorf("expected the insecure config to survive when the CA source has nothing to offer")
+	}
+}
+
+func TestCreateConfigForLoginOnlyFetchesCAWhenFlagIsSet(t *testing.T) {
+	clientCfg := &client.Config{Host: "https://foo.example.com", Insecure: true}
+	clusterNick, err := getClusterNickname(clientCfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	source := &fakeCASource{ca: []byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n")}
+
+	config, err := CreateConfigForLogin("myproject", clientCfg, source, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.Clusters[clusterNick].InsecureSkipTLSVerify {
+		t.Errorf("expected the insecure config to be left alone when --fetch-ca-from-cluster wasn't passed")
+	}
+
+	config, err = CreateConfigForLogin("myproject", clientCfg, source, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Clusters[clusterNick].InsecureSkipTLSVerify {
+		t.Errorf("expected --fetch-ca-from-cluster to replace the insecure config with the fetched CA")
+	}
+}
+
+func TestBindFetchCAFromClusterFlag(t *testing.T) {
+	var fetchFromCluster bool
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	BindFetchCAFromClusterFlag(flagSet, &fetchFromCluster)
+
+	if err := flagSet.Parse([]string{"--fetch-ca-from-cluster"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fetchFromCluster {
+		t.Errorf("expected --fetch-ca-from-cluster to set fetchFromCluster")
+	}
+}
+
+func TestFileCASourceReadsFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "ca")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n")
+	f.Close()
+
+	source := &FileCASource{Path: f.Name()}
+	ca, err := source.GetCA(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ca) == 0 {
+		t.Errorf("expected CA bytes to be read from file")
+	}
+}
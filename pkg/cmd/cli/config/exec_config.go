@@ -0,0 +1,80 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+)
+
+// ExecCredentialPluginFlags holds the values collected from the --exec-command, --exec-arg,
+// --exec-env and --exec-api-version flags on "oc login" and "oc config set-credentials".
+type ExecCredentialPluginFlags struct {
+	APIVersion string
+	Command    string
+	Args       []string
+	Env        []string
+}
+
+// BindExecCredentialPluginFlags registers --exec-command, --exec-arg, --exec-env and
+// --exec-api-version on flags, writing into f.  "oc login" and "oc config set-credentials" would
+// call this when building their command, then call f.ToExecConfig() once flags have been parsed -
+// neither command's package is part of this tree's extraction, so there's no caller of this yet.
+func (f *ExecCredentialPluginFlags) BindExecCredentialPluginFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.Command, "exec-command", f.Command, "Command to run to obtain credentials via the client.authentication.k8s.io exec protocol")
+	flags.StringArrayVar(&f.Args, "exec-arg", f.Args, "Argument to pass the exec credential plugin; may be repeated")
+	flags.StringArrayVar(&f.Env, "exec-env", f.Env, "NAME=VALUE environment variable to set for the exec credential plugin; may be repeated")
+	flags.StringVar(&f.APIVersion, "exec-api-version", "client.authentication.k8s.io/v1beta1", "client.authentication.k8s.io version the exec credential plugin speaks")
+}
+
+// ToExecConfig converts the flag values into the clientcmdapi.ExecConfig stanza that
+// CreateConfigWithExecCredential embeds in the generated AuthInfo.  It returns nil when the user
+// didn't ask for an exec-based credential plugin, so CreateConfigWithExecCredential keeps
+// CreateConfig's static token/client-cert path.
+func (f *ExecCredentialPluginFlags) ToExecConfig() *clientcmdapi.ExecConfig {
+	if f == nil || len(f.Command) == 0 {
+		return nil
+	}
+
+	exec := &clientcmdapi.ExecConfig{
+		APIVersion: f.APIVersion,
+		Command:    f.Command,
+		Args:       f.Args,
+	}
+	for _, env := range f.Env {
+		parts := strings.SplitN(env, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: parts[0], Value: parts[1]})
+	}
+
+	return exec
+}
+
+// CreateConfigWithExecCredential behaves like CreateConfig, but additionally embeds execConfig
+// (typically ExecCredentialPluginFlags.ToExecConfig()'s return value) into the generated
+// AuthInfo's Exec stanza, so the kubeconfig shells out to a credential plugin instead of carrying
+// a static token or client certificate.  A nil execConfig makes this identical to CreateConfig.
+func CreateConfigWithExecCredential(namespace string, clientCfg *client.Config, execConfig *clientcmdapi.ExecConfig) (*clientcmdapi.Config, error) {
+	config, err := CreateConfig(namespace, clientCfg)
+	if err != nil {
+		return nil, err
+	}
+	if execConfig == nil {
+		return config, nil
+	}
+
+	userNick, err := getUserNickname(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	authInfo := config.AuthInfos[userNick]
+	authInfo.Exec = execConfig
+	config.AuthInfos[userNick] = authInfo
+
+	return config, nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+)
+
+func TestBindExecCredentialPluginFlagsRoundTrip(t *testing.T) {
+	flags := &ExecCredentialPluginFlags{}
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.BindExecCredentialPluginFlags(flagSet)
+
+	if err := flagSet.Parse([]string{
+		"--exec-command=/usr/bin/token-helper",
+		"--exec-arg=--cluster=prod",
+		"--exec-env=FOO=bar",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exec := flags.ToExecConfig()
+	if exec == nil {
+		t.Fatalf("expected a non-nil ExecConfig once --exec-command is set")
+	}
+	if exec.Command != "/usr/bin/token-helper" {
+		t.Errorf("expected command to be bound, got %q", exec.Command)
+	}
+	if len(exec.Args) != 1 || exec.Args[0] != "--cluster=prod" {
+		t.Errorf("expected args to be bound, got %v", exec.Args)
+	}
+	if len(exec.Env) != 1 || exec.Env[0].Name != "FOO" || exec.Env[0].Value != "bar" {
+		t.Errorf("expected env to be bound, got %v", exec.Env)
+	}
+}
+
+func TestToExecConfigNilWithoutCommand(t *testing.T) {
+	flags := &ExecCredentialPluginFlags{}
+	if exec := flags.ToExecConfig(); exec != nil {
+		t.Errorf("expected no ExecConfig when --exec-command wasn't set, got %+v", exec)
+	}
+}
+
+func TestCreateConfigWithExecCredentialEmbedsExecStanza(t *testing.T) {
+	flags := &ExecCredentialPluginFlags{Command: "/usr/bin/token-helper"}
+
+	clientCfg := &client.Config{Host: "https://foo.example.com"}
+	config, err := CreateConfigWithExecCredential("myproject", clientCfg, flags.ToExecConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	userNick, err := getUserNickname(clientCfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	authInfo := config.AuthInfos[userNick]
+	if authInfo.Exec == nil || authInfo.Exec.Command != "/usr/bin/token-helper" {
+		t.Errorf("expected the generated AuthInfo to carry the exec stanza, got %+v", authInfo.Exec)
+	}
+}
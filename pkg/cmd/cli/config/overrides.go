@@ -0,0 +1,56 @@
+package config
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+)
+
+// ConfigOverrides lets a caller layer explicit cluster/auth/context values on top of whatever
+// CreateConfig derives from the live clientCfg, mirroring upstream clientcmd's ConfigOverrides.
+type ConfigOverrides struct {
+	ClusterInfo     clientcmdapi.Cluster
+	AuthInfo        clientcmdapi.AuthInfo
+	Context         clientcmdapi.Context
+	CurrentContext  string
+	ClusterDefaults clientcmdapi.Cluster
+}
+
+// CreateConfigWithOverrides builds the same config CreateConfig would, then layers
+// overrides.ClusterDefaults underneath and overrides.{ClusterInfo,AuthInfo,Context} on top of the
+// values pulled from clientCfg.  Any override field that's left empty doesn't touch the value
+// CreateConfig already derived.
+func CreateConfigWithOverrides(namespace string, clientCfg *client.Config, overrides ConfigOverrides) (*clientcmdapi.Config, error) {
+	config, err := CreateConfig(namespace, clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterNick, err := getClusterNickname(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+	userNick, err := getUserNickname(clientCfg)
+	if err != nil {
+		return nil, err
+	}
+	contextNick, err := getContextNickname(namespace, clientCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// MergeOptions{} keeps the field merge's usual rule: a non-empty field on the right-hand side
+	// wins, an empty one leaves the left-hand side untouched.  That's exactly "defaults fill
+	// blanks, explicit overrides win" when applied in this order.
+	withDefaults := mergeFields(overrides.ClusterDefaults, config.Clusters[clusterNick], MergeOptions{}, nil).(clientcmdapi.Cluster)
+	config.Clusters[clusterNick] = mergeFields(withDefaults, overrides.ClusterInfo, MergeOptions{}, nil).(clientcmdapi.Cluster)
+	config.AuthInfos[userNick] = mergeFields(config.AuthInfos[userNick], overrides.AuthInfo, MergeOptions{}, nil).(clientcmdapi.AuthInfo)
+	config.Contexts[contextNick] = mergeFields(config.Contexts[contextNick], overrides.Context, MergeOptions{}, nil).(clientcmdapi.Context)
+
+	if len(overrides.CurrentContext) > 0 {
+		config.CurrentContext = overrides.CurrentContext
+	} else {
+		config.CurrentContext = contextNick
+	}
+
+	return config, nil
+}
@@ -115,16 +115,56 @@ func CreateConfig(namespace string, clientCfg *client.Config) (*clientcmdapi.Con
 	return config, nil
 }
 
-// MergeConfig adds the additional Config stanzas to the startingConfig.  It blindly stomps clusters and users, but
-// it searches for a matching context before writing a new one.
-func MergeConfig(startingConfig, addition clientcmdapi.Config) (*clientcmdapi.Config, error) {
+// MergeOptions controls how MergeConfig reconciles a Cluster/AuthInfo/Context that already exists
+// in the starting config with the version carried in the addition, instead of blindly stomping it.
+type MergeOptions struct {
+	// OverwriteNonEmpty, when true, lets every field on the addition - even an empty one - replace
+	// the matching field on the starting config, which is the old blind-stomp behavior applied
+	// per field rather than per stanza.  When false (the default), a field left empty on the
+	// addition is skipped so it can't wipe out a field the starting config already had set.
+	OverwriteNonEmpty bool
+
+	// PreserveCAData, when true, never lets the addition overwrite a CertificateAuthority or
+	// CertificateAuthorityData that's already set, even if OverwriteNonEmpty is true.  This keeps
+	// a credential rotation from silently reverting a cluster to a less-trusted CA.
+	PreserveCAData bool
+
+	// AllowedFields restricts the merge to the named struct fields; any other field on the
+	// addition is ignored and the starting config's value is kept.  A nil/empty slice allows
+	// every field to participate.
+	AllowedFields []string
+}
+
+// pinnedCAFields are the Cluster fields PreserveCAData refuses to let the addition overwrite.
+var pinnedCAFields = map[string]bool{
+	"CertificateAuthority":     true,
+	"CertificateAuthorityData": true,
+}
+
+// MergeConfig adds the additional Config stanzas to the startingConfig.  Clusters and AuthInfos are
+// merged field-by-field according to options instead of being stomped wholesale, so a partial
+// addition (e.g. one that only carries a rotated token) can't erase fields it left blank.  It
+// searches for a matching context before writing a new one.
+//
+// There are no callers of this function in this tree yet (no "oc config"/"oc login" command
+// package exists here) - the "options MergeOptions" parameter is part of this change's own API, not
+// a signature change an existing caller needs to be updated for.
+func MergeConfig(startingConfig, addition clientcmdapi.Config, options MergeOptions) (*clientcmdapi.Config, error) {
 	ret := startingConfig
 
 	for requestedKey, value := range addition.Clusters {
+		if existing, exists := ret.Clusters[requestedKey]; exists {
+			ret.Clusters[requestedKey] = mergeFields(existing, value, options, pinnedCAFields).(clientcmdapi.Cluster)
+			continue
+		}
 		ret.Clusters[requestedKey] = value
 	}
 
 	for requestedKey, value := range addition.AuthInfos {
+		if existing, exists := ret.AuthInfos[requestedKey]; exists {
+			ret.AuthInfos[requestedKey] = mergeFields(existing, value, options, nil).(clientcmdapi.AuthInfo)
+			continue
+		}
 		ret.AuthInfos[requestedKey] = value
 	}
 
@@ -157,14 +197,82 @@ func MergeConfig(startingConfig, addition clientcmdapi.Config) (*clientcmdapi.Co
 	return &ret, nil
 }
 
-// FindExistingContextName finds the nickname for the passed context
+// mergeFields walks the fields of existing/addition (which must be the same struct type) and
+// returns a copy of existing with fields from addition layered on top according to options and
+// the caller-supplied pinned set (fields that, when options.PreserveCAData is set, are never
+// overwritten once set, regardless of options.OverwriteNonEmpty).
+func mergeFields(existing, addition interface{}, options MergeOptions, pinned map[string]bool) interface{} {
+	allowed := map[string]bool{}
+	for _, name := range options.AllowedFields {
+		allowed[name] = true
+	}
+
+	result := reflect.New(reflect.TypeOf(existing)).Elem()
+	result.Set(reflect.ValueOf(existing))
+	additionValue := reflect.ValueOf(addition)
+
+	structType := result.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		fieldName := structType.Field(i).Name
+
+		if len(allowed) > 0 && !allowed[fieldName] {
+			continue
+		}
+		if pinned[fieldName] && options.PreserveCAData && !isZero(result.Field(i)) {
+			continue
+		}
+
+		additionField := additionValue.Field(i)
+		if isZero(additionField) && !options.OverwriteNonEmpty {
+			continue
+		}
+		if !isZero(additionField) || options.OverwriteNonEmpty {
+			result.Field(i).Set(additionField)
+		}
+	}
+
+	return result.Interface()
+}
+
+// isZero reports whether v holds its type's zero value.
+func isZero(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// FindExistingContextName finds the nickname for the passed context.  Any field left empty on
+// needle is treated as a wildcard so a partial context (e.g. just {Cluster, Namespace}) can still
+// match an existing, more fully-specified one.  The match must be unique: an entirely empty needle,
+// or a partial needle that matches more than one context, returns "" rather than an arbitrary
+// context picked by Go's randomized map iteration order.
 func FindExistingContextName(haystack clientcmdapi.Config, needle clientcmdapi.Context) string {
+	if len(needle.Cluster) == 0 && len(needle.AuthInfo) == 0 && len(needle.Namespace) == 0 && len(needle.Extensions) == 0 {
+		return ""
+	}
+
+	match := ""
+	matches := 0
 	for key, context := range haystack.Contexts {
 		context.LocationOfOrigin = ""
-		if reflect.DeepEqual(context, needle) {
-			return key
+
+		if len(needle.Cluster) > 0 && context.Cluster != needle.Cluster {
+			continue
+		}
+		if len(needle.AuthInfo) > 0 && context.AuthInfo != needle.AuthInfo {
+			continue
+		}
+		if len(needle.Namespace) > 0 && context.Namespace != needle.Namespace {
+			continue
+		}
+		if len(needle.Extensions) > 0 && !reflect.DeepEqual(context.Extensions, needle.Extensions) {
+			continue
 		}
+
+		matches++
+		match = key
 	}
 
-	return ""
+	if matches != 1 {
+		return ""
+	}
+	return match
 }
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"testing"
+
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+)
+
+func TestMergeConfigPartialClusterUpdatePreservesCAData(t *testing.T) {
+	starting := clientcmdapi.NewConfig()
+	starting.Clusters["foo"] = clientcmdapi.Cluster{
+		Server:                   "https://foo.example.com",
+		CertificateAuthorityData: []byte("original-ca"),
+	}
+
+	addition := clientcmdapi.NewConfig()
+	addition.Clusters["foo"] = clientcmdapi.Cluster{
+		Server: "https://foo.example.com:8443",
+	}
+
+	merged, err := MergeConfig(*starting, *addition, MergeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cluster := merged.Clusters["foo"]
+	if cluster.Server != "https://foo.example.com:8443" {
+		t.Errorf("expected server to be updated, got %q", cluster.Server)
+	}
+	if string(cluster.CertificateAuthorityData) != "original-ca" {
+		t.Errorf("expected CertificateAuthorityData to survive the merge, got %q", cluster.CertificateAuthorityData)
+	}
+}
+
+func TestMergeConfigTokenRotationPreservesClientCert(t *testing.T) {
+	starting := clientcmdapi.NewConfig()
+	starting.AuthInfos["foo"] = clientcmdapi.AuthInfo{
+		Token:                 "old-token",
+		ClientCertificateData: []byte("original-cert"),
+		ClientKeyData:         []byte("original-key"),
+	}
+
+	addition := clientcmdapi.NewConfig()
+	addition.AuthInfos["foo"] = clientcmdapi.AuthInfo{
+		Token: "new-token",
+	}
+
+	// A partial, token-only addition uses the default options, the same as
+	// TestMergeConfigPartialClusterUpdatePreservesCAData: fields left blank on the addition are
+	// skipped rather than overwritten.  OverwriteNonEmpty is for a caller replacing a config
+	// wholesale, where even an explicit zero value (e.g. turning InsecureSkipTLSVerify back off)
+	// needs to win - that's not this scenario.
+	merged, err := MergeConfig(*starting, *addition, MergeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	authInfo := merged.AuthInfos["foo"]
+	if authInfo.Token != "new-token" {
+		t.Errorf("expected token to be rotated, got %q", authInfo.Token)
+	}
+	if string(authInfo.ClientCertificateData) != "original-cert" {
+		t.Errorf("expected ClientCertificateData to survive rotation, got %q", authInfo.ClientCertificateData)
+	}
+	if string(authInfo.ClientKeyData) != "original-key" {
+		t.Errorf("expected ClientKeyData to survive rotation, got %q", authInfo.ClientKeyData)
+	}
+}
+
+func TestMergeConfigPreserveCADataBlocksOverwrite(t *testing.T) {
+	starting := clientcmdapi.NewConfig()
+	starting.Clusters["foo"] = clientcmdapi.Cluster{
+		CertificateAuthorityData: []byte("trusted-ca"),
+	}
+
+	addition := clientcmdapi.NewConfig()
+	addition.Clusters["foo"] = clientcmdapi.Cluster{
+		CertificateAuthorityData: []byte("untrusted-ca"),
+	}
+
+	merged, err := MergeConfig(*starting, *addition, MergeOptions{OverwriteNonEmpty: true, PreserveCAData: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(merged.Clusters["foo"].CertificateAuthorityData) != "trusted-ca" {
+		t.Errorf("expected PreserveCAData to keep the original CA, got %q", merged.Clusters["foo"].CertificateAuthorityData)
+	}
+}
+
+func TestMergeConfigPreserveCADataFalseAllowsOverwrite(t *testing.T) {
+	starting := clientcmdapi.NewConfig()
+	starting.Clusters["foo"] = clientcmdapi.Cluster{
+		CertificateAuthorityData: []byte("old-ca"),
+	}
+
+	addition := clientcmdapi.NewConfig()
+	addition.Clusters["foo"] = clientcmdapi.Cluster{
+		CertificateAuthorityData: []byte("rotated-ca"),
+	}
+
+	merged, err := MergeConfig(*starting, *addition, MergeOptions{OverwriteNonEmpty: true, PreserveCAData: false})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := string(merged.Clusters["foo"].CertificateAuthorityData); got != "rotated-ca" {
+		t.Errorf("expected an intentional CA rotation to replace the CA when PreserveCAData is false, got %q", got)
+	}
+}
+
+func TestFindExistingContextNameMatchesPartialContext(t *testing.T) {
+	haystack := clientcmdapi.NewConfig()
+	haystack.Contexts["existing"] = clientcmdapi.Context{
+		Cluster:   "foo-cluster",
+		AuthInfo:  "foo-user",
+		Namespace: "foo-namespace",
+	}
+
+	needle := clientcmdapi.Context{
+		Cluster:   "foo-cluster",
+		Namespace: "foo-namespace",
+	}
+
+	if name := FindExistingContextName(*haystack, needle); name != "existing" {
+		t.Errorf("expected partial context to match \"existing\", got %q", name)
+	}
+}
+
+func TestFindExistingContextNameAmbiguousMatchReturnsEmpty(t *testing.T) {
+	haystack := clientcmdapi.NewConfig()
+	haystack.Contexts["first"] = clientcmdapi.Context{
+		Cluster:   "foo-cluster",
+		AuthInfo:  "foo-user",
+		Namespace: "foo-namespace",
+	}
+	haystack.Contexts["second"] = clientcmdapi.Context{
+		Cluster:   "foo-cluster",
+		AuthInfo:  "bar-user",
+		Namespace: "foo-namespace",
+	}
+
+	needle := clientcmdapi.Context{
+		Cluster:   "foo-cluster",
+		Namespace: "foo-namespace",
+	}
+
+	if name := FindExistingContextName(*haystack, needle); len(name) != 0 {
+		t.Errorf("expected an ambiguous partial match to return no name, got %q", name)
+	}
+
+	if name := FindExistingContextName(*haystack, clientcmdapi.Context{}); len(name) != 0 {
+		t.Errorf("expected an entirely empty needle to match nothing, got %q", name)
+	}
+}
+
+func TestMergeConfigAddsNamespaceOnlyContext(t *testing.T) {
+	starting := clientcmdapi.NewConfig()
+	starting.Clusters["foo-cluster"] = clientcmdapi.Cluster{Server: "https://foo.example.com"}
+	starting.AuthInfos["foo-user"] = clientcmdapi.AuthInfo{Token: "token"}
+
+	addition := clientcmdapi.NewConfig()
+	addition.Contexts["foo-context"] = clientcmdapi.Context{
+		Cluster:   "foo-cluster",
+		AuthInfo:  "foo-user",
+		Namespace: "a-new-namespace",
+	}
+	addition.CurrentContext = "foo-context"
+
+	merged, err := MergeConfig(*starting, *addition, MergeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	context, exists := merged.Contexts["foo-context"]
+	if !exists {
+		t.Fatalf("expected foo-context to be added")
+	}
+	if context.Namespace != "a-new-namespace" {
+		t.Errorf("expected namespace to be set, got %q", context.Namespace)
+	}
+	if merged.CurrentContext != "foo-context" {
+		t.Errorf("expected current context to be updated, got %q", merged.CurrentContext)
+	}
+}
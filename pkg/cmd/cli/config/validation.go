@@ -0,0 +1,162 @@
+package config
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+	utilerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/util/errors"
+)
+
+// ValidateAndConfirmUsable is the call "oc login" and "oc config view" are expected to make before
+// writing config to disk: it runs Validate across every stanza, and if that passes, ConfirmUsable
+// against contextName so a problem with that context (e.g. a client certificate/key pair that
+// doesn't load) is caught even if every stanza is individually well-formed.  There's no "oc login"/"oc config view" command package in this tree
+// yet to update in place, so this is the integration point such a command would call.
+func ValidateAndConfirmUsable(config clientcmdapi.Config, contextName string) error {
+	if err := Validate(config); err != nil {
+		return err
+	}
+	return ConfirmUsable(config, contextName)
+}
+
+// Validate runs the structural checks upstream clientcmd performs before writing a kubeconfig to
+// disk: every server URL parses, every referenced CA file exists and decodes, and every context's
+// cluster/user references resolve.  It collects every problem it finds instead of stopping at the
+// first one, so "oc login"/"oc config view" can report all of them at once.
+func Validate(config clientcmdapi.Config) utilerrors.Aggregate {
+	errs := []error{}
+
+	for name, cluster := range config.Clusters {
+		errs = append(errs, validateClusterInfo(name, cluster)...)
+	}
+	for name, context := range config.Contexts {
+		errs = append(errs, validateContext(name, context, config)...)
+	}
+	if len(config.CurrentContext) > 0 {
+		if _, exists := config.Contexts[config.CurrentContext]; !exists {
+			errs = append(errs, fmt.Errorf("current context %q does not exist", config.CurrentContext))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateClusterInfo(name string, cluster clientcmdapi.Cluster) []error {
+	errs := []error{}
+
+	if len(cluster.Server) == 0 {
+		errs = append(errs, fmt.Errorf("cluster %q has no server set", name))
+	} else if u, err := url.ParseRequestURI(cluster.Server); err != nil {
+		errs = append(errs, fmt.Errorf("cluster %q has an invalid server URL: %v", name, err))
+	} else if len(u.Scheme) == 0 || len(u.Host) == 0 {
+		errs = append(errs, fmt.Errorf("cluster %q server URL %q must be an absolute URL with a scheme and host", name, cluster.Server))
+	}
+
+	hasCA := len(cluster.CertificateAuthority) > 0 || len(cluster.CertificateAuthorityData) > 0
+	if cluster.InsecureSkipTLSVerify && hasCA {
+		errs = append(errs, fmt.Errorf("cluster %q sets InsecureSkipTLSVerify and a certificate authority; these are mutually exclusive", name))
+	}
+
+	caData := cluster.CertificateAuthorityData
+	if len(cluster.CertificateAuthority) > 0 {
+		data, err := ioutil.ReadFile(cluster.CertificateAuthority)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cluster %q certificate authority %q: %v", name, cluster.CertificateAuthority, err))
+		} else {
+			caData = data
+		}
+	}
+	if len(caData) > 0 {
+		if block, _ := pem.Decode(caData); block == nil {
+			errs = append(errs, fmt.Errorf("cluster %q certificate authority is not valid PEM data", name))
+		}
+	}
+
+	return errs
+}
+
+func validateContext(name string, context clientcmdapi.Context, config clientcmdapi.Config) []error {
+	errs := []error{}
+
+	if len(context.Cluster) == 0 {
+		errs = append(errs, fmt.Errorf("context %q has no cluster set", name))
+	} else if _, exists := config.Clusters[context.Cluster]; !exists {
+		errs = append(errs, fmt.Errorf("context %q references cluster %q which does not exist", name, context.Cluster))
+	}
+
+	if len(context.AuthInfo) == 0 {
+		errs = append(errs, fmt.Errorf("context %q has no user set", name))
+	} else if _, exists := config.AuthInfos[context.AuthInfo]; !exists {
+		errs = append(errs, fmt.Errorf("context %q references user %q which does not exist", name, context.AuthInfo))
+	}
+
+	return errs
+}
+
+// ConfirmUsable looks up contextName in config (falling back to the current context when
+// contextName is empty) and confirms it, and the cluster/user it references, are concrete enough
+// to actually dial the API server with.
+func ConfirmUsable(config clientcmdapi.Config, contextName string) error {
+	if len(contextName) == 0 {
+		contextName = config.CurrentContext
+	}
+	if len(contextName) == 0 {
+		return fmt.Errorf("no context is current")
+	}
+
+	context, exists := config.Contexts[contextName]
+	if !exists {
+		return fmt.Errorf("context %q does not exist", contextName)
+	}
+
+	cluster, exists := config.Clusters[context.Cluster]
+	if !exists {
+		return fmt.Errorf("context %q references cluster %q which does not exist", contextName, context.Cluster)
+	}
+	if len(cluster.Server) == 0 {
+		return fmt.Errorf("cluster %q has no server set", context.Cluster)
+	}
+
+	authInfo, exists := config.AuthInfos[context.AuthInfo]
+	if !exists {
+		return fmt.Errorf("context %q references user %q which does not exist", contextName, context.AuthInfo)
+	}
+
+	return confirmCertKeyPair(authInfo)
+}
+
+// confirmCertKeyPair makes sure a client certificate, if one is configured, is actually paired
+// with a matching key.
+func confirmCertKeyPair(authInfo clientcmdapi.AuthInfo) error {
+	certData, certFile := authInfo.ClientCertificateData, authInfo.ClientCertificate
+	keyData, keyFile := authInfo.ClientKeyData, authInfo.ClientKey
+
+	if len(certData) == 0 && len(certFile) == 0 && len(keyData) == 0 && len(keyFile) == 0 {
+		return nil
+	}
+
+	if len(certData) == 0 {
+		data, err := ioutil.ReadFile(certFile)
+		if err != nil {
+			return fmt.Errorf("client certificate %q: %v", certFile, err)
+		}
+		certData = data
+	}
+	if len(keyData) == 0 {
+		data, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return fmt.Errorf("client key %q: %v", keyFile, err)
+		}
+		keyData = data
+	}
+
+	if _, err := tls.X509KeyPair(certData, keyData); err != nil {
+		return fmt.Errorf("client certificate/key pair is invalid: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+)
+
+func TestValidateCatchesDanglingContextReferences(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+	config.Contexts["broken"] = clientcmdapi.Context{Cluster: "missing-cluster", AuthInfo: "missing-user"}
+
+	errs := Validate(*config)
+	if errs == nil {
+		t.Fatalf("expected validation errors for dangling references")
+	}
+	if len(errs.Errors()) != 2 {
+		t.Fatalf("expected 2 errors (missing cluster and missing user), got %v", errs.Errors())
+	}
+}
+
+func TestValidateRejectsInsecureWithCAData(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["foo"] = clientcmdapi.Cluster{
+		Server:                   "https://foo.example.com",
+		InsecureSkipTLSVerify:    true,
+		CertificateAuthorityData: []byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"),
+	}
+
+	if errs := Validate(*config); errs == nil {
+		t.Fatalf("expected an error for Insecure combined with a certificate authority")
+	}
+}
+
+func TestConfirmUsableRequiresCurrentContext(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+
+	if err := ConfirmUsable(*config, ""); err == nil {
+		t.Fatalf("expected an error when no context is current")
+	}
+}
+
+func TestValidateRejectsServerURLWithoutSchemeOrHost(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["foo"] = clientcmdapi.Cluster{Server: "not a url"}
+
+	errs := Validate(*config)
+	if errs == nil {
+		t.Fatalf("expected an error for a server value that isn't an absolute URL")
+	}
+}
+
+func TestValidateAndConfirmUsableSurfacesValidationErrors(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["foo"] = clientcmdapi.Cluster{Server: "not a url"}
+	config.AuthInfos["foo"] = clientcmdapi.AuthInfo{}
+	config.Contexts["foo"] = clientcmdapi.Context{Cluster: "foo", AuthInfo: "foo"}
+
+	if err := ValidateAndConfirmUsable(*config, "foo"); err == nil {
+		t.Fatalf("expected the invalid server URL to be caught before ConfirmUsable runs")
+	}
+}